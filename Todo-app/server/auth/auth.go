@@ -0,0 +1,217 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// User represents an account stored in the `users` collection. Passwords are
+// never marshalled back out to clients.
+type User struct {
+	Username string `json:"username" bson:"username"`
+	Password string `json:"-" bson:"password"`
+}
+
+type contextKey string
+
+const userIDKey contextKey = "userID"
+
+// Claims is the JWT payload issued on login. The subject carries the
+// username since that's the only identifier the current schema has.
+type Claims struct {
+	Username string `json:"username"`
+	jwt.RegisteredClaims
+}
+
+// Handler wires the auth endpoints and middleware to a Mongo client. It
+// mirrors the package-level handler style used elsewhere in this service.
+type Handler struct {
+	client *mongo.Client
+	secret []byte
+}
+
+// NewHandler builds an auth Handler backed by the given Mongo client. The
+// signing secret is read from JWT_SECRET; falling back to a fixed dev value
+// keeps `go run` working out of the box, but it must be set in production.
+func NewHandler(client *mongo.Client) *Handler {
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		secret = "dev-secret-change-me"
+	}
+	return &Handler{client: client, secret: []byte(secret)}
+}
+
+func (h *Handler) collection() *mongo.Collection {
+	return h.client.Database("todos-app").Collection("users")
+}
+
+// EnsureIndexes creates the unique index on username. Call once at startup.
+func (h *Handler) EnsureIndexes(ctx context.Context) error {
+	_, err := h.collection().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.M{"username": 1},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+type credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// RegisterEndpoint creates a new user with a bcrypt-hashed password.
+func (h *Handler) RegisterEndpoint(response http.ResponseWriter, request *http.Request) {
+	response.Header().Set("Content-Type", "application/json")
+	var creds credentials
+	if err := json.NewDecoder(request.Body).Decode(&creds); err != nil || creds.Username == "" || creds.Password == "" {
+		response.WriteHeader(http.StatusBadRequest)
+		response.Write([]byte(`{"message": "Invalid request payload"}`))
+		return
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(creds.Password), bcrypt.DefaultCost)
+	if err != nil {
+		response.WriteHeader(http.StatusInternalServerError)
+		response.Write([]byte(`{"message": "Failed to hash password"}`))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = h.collection().InsertOne(ctx, User{Username: creds.Username, Password: string(hashed)})
+	if mongo.IsDuplicateKeyError(err) {
+		response.WriteHeader(http.StatusConflict)
+		response.Write([]byte(`{"message": "Username already taken"}`))
+		return
+	}
+	if err != nil {
+		response.WriteHeader(http.StatusInternalServerError)
+		response.Write([]byte(`{"message": "Failed to create user"}`))
+		return
+	}
+
+	response.WriteHeader(http.StatusCreated)
+	json.NewEncoder(response).Encode(map[string]string{"username": creds.Username})
+}
+
+// ErrInvalidCredentials is returned by Authenticate when the username is
+// unknown or the password doesn't match.
+var ErrInvalidCredentials = errors.New("auth: invalid username or password")
+
+// Authenticate checks a username/password pair against the users
+// collection. It's shared by the JSON login endpoint and the server-
+// rendered UI's login form.
+func (h *Handler) Authenticate(ctx context.Context, username, password string) (string, error) {
+	var user User
+	err := h.collection().FindOne(ctx, bson.M{"username": username}).Decode(&user)
+	if err != nil || bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)) != nil {
+		return "", ErrInvalidCredentials
+	}
+	return user.Username, nil
+}
+
+// IssueToken signs a JWT for the given username, valid for 24 hours.
+func (h *Handler) IssueToken(username string) (string, error) {
+	claims := Claims{
+		Username: username,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   username,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(h.secret)
+}
+
+// VerifyToken parses and validates a signed JWT, returning the username it
+// was issued for.
+func (h *Handler) VerifyToken(tokenString string) (string, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return h.secret, nil
+	})
+	if err != nil || !token.Valid {
+		return "", errors.New("auth: invalid or expired token")
+	}
+	return claims.Username, nil
+}
+
+// LoginEndpoint verifies credentials and issues a signed JWT.
+func (h *Handler) LoginEndpoint(response http.ResponseWriter, request *http.Request) {
+	response.Header().Set("Content-Type", "application/json")
+	var creds credentials
+	if err := json.NewDecoder(request.Body).Decode(&creds); err != nil {
+		response.WriteHeader(http.StatusBadRequest)
+		response.Write([]byte(`{"message": "Invalid request payload"}`))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	username, err := h.Authenticate(ctx, creds.Username, creds.Password)
+	if err != nil {
+		response.WriteHeader(http.StatusUnauthorized)
+		response.Write([]byte(`{"message": "Invalid username or password"}`))
+		return
+	}
+
+	token, err := h.IssueToken(username)
+	if err != nil {
+		response.WriteHeader(http.StatusInternalServerError)
+		response.Write([]byte(`{"message": "Failed to issue token"}`))
+		return
+	}
+
+	json.NewEncoder(response).Encode(map[string]string{"token": token})
+}
+
+// Middleware validates the Authorization: Bearer header and injects the
+// authenticated username into the request context.
+func (h *Handler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		header := request.Header.Get("Authorization")
+		tokenString := strings.TrimPrefix(header, "Bearer ")
+		if tokenString == "" || tokenString == header {
+			response.WriteHeader(http.StatusUnauthorized)
+			response.Write([]byte(`{"message": "Missing bearer token"}`))
+			return
+		}
+
+		username, err := h.VerifyToken(tokenString)
+		if err != nil {
+			response.WriteHeader(http.StatusUnauthorized)
+			response.Write([]byte(`{"message": "Invalid or expired token"}`))
+			return
+		}
+
+		ctx := context.WithValue(request.Context(), userIDKey, username)
+		next.ServeHTTP(response, request.WithContext(ctx))
+	})
+}
+
+// UserIDFromContext returns the authenticated username stored by Middleware.
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	username, ok := ctx.Value(userIDKey).(string)
+	return username, ok
+}
+
+// ContextWithUserID attaches an authenticated username to ctx, the same way
+// Middleware does. Used by callers that authenticate outside of the bearer
+// token flow, such as the UI's cookie session.
+func ContextWithUserID(ctx context.Context, username string) context.Context {
+	return context.WithValue(ctx, userIDKey, username)
+}
@@ -0,0 +1,143 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestIssueTokenAndVerifyTokenRoundTrip(t *testing.T) {
+	handler := &Handler{secret: []byte("test-secret")}
+
+	token, err := handler.IssueToken("alice")
+	if err != nil {
+		t.Fatalf("IssueToken: %v", err)
+	}
+
+	username, err := handler.VerifyToken(token)
+	if err != nil {
+		t.Fatalf("VerifyToken: %v", err)
+	}
+	if username != "alice" {
+		t.Errorf("username = %q, want %q", username, "alice")
+	}
+}
+
+func TestVerifyTokenRejectsWrongSecret(t *testing.T) {
+	issuer := &Handler{secret: []byte("issuer-secret")}
+	verifier := &Handler{secret: []byte("different-secret")}
+
+	token, err := issuer.IssueToken("alice")
+	if err != nil {
+		t.Fatalf("IssueToken: %v", err)
+	}
+
+	if _, err := verifier.VerifyToken(token); err == nil {
+		t.Error("VerifyToken succeeded with the wrong secret, want error")
+	}
+}
+
+func TestVerifyTokenRejectsExpiredToken(t *testing.T) {
+	handler := &Handler{secret: []byte("test-secret")}
+
+	claims := Claims{
+		Username: "alice",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(time.Now().Add(-2 * time.Hour)),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(handler.secret)
+	if err != nil {
+		t.Fatalf("signing expired token: %v", err)
+	}
+
+	if _, err := handler.VerifyToken(token); err == nil {
+		t.Error("VerifyToken succeeded with an expired token, want error")
+	}
+}
+
+func TestVerifyTokenRejectsWrongSigningMethod(t *testing.T) {
+	handler := &Handler{secret: []byte("test-secret")}
+
+	claims := Claims{Username: "alice"}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodNone, claims).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("signing none-alg token: %v", err)
+	}
+
+	if _, err := handler.VerifyToken(token); err == nil {
+		t.Error("VerifyToken accepted a token signed with \"none\", want error")
+	}
+}
+
+func TestMiddlewareRejectsMissingAuthorizationHeader(t *testing.T) {
+	handler := &Handler{secret: []byte("test-secret")}
+	called := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { called = true })
+
+	request := httptest.NewRequest("GET", "/todos", nil)
+	response := httptest.NewRecorder()
+
+	handler.Middleware(next).ServeHTTP(response, request)
+
+	if called {
+		t.Error("next handler ran without an Authorization header")
+	}
+	if response.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", response.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestMiddlewareRejectsMalformedBearerPrefix(t *testing.T) {
+	handler := &Handler{secret: []byte("test-secret")}
+	called := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { called = true })
+
+	token, err := handler.IssueToken("alice")
+	if err != nil {
+		t.Fatalf("IssueToken: %v", err)
+	}
+
+	request := httptest.NewRequest("GET", "/todos", nil)
+	request.Header.Set("Authorization", "Token "+token) // wrong scheme, not "Bearer "
+	response := httptest.NewRecorder()
+
+	handler.Middleware(next).ServeHTTP(response, request)
+
+	if called {
+		t.Error("next handler ran with a malformed Authorization scheme")
+	}
+	if response.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", response.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestMiddlewareAcceptsValidBearerToken(t *testing.T) {
+	handler := &Handler{secret: []byte("test-secret")}
+	var gotUsername string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUsername, _ = UserIDFromContext(r.Context())
+	})
+
+	token, err := handler.IssueToken("alice")
+	if err != nil {
+		t.Fatalf("IssueToken: %v", err)
+	}
+
+	request := httptest.NewRequest("GET", "/todos", nil)
+	request.Header.Set("Authorization", "Bearer "+token)
+	response := httptest.NewRecorder()
+
+	handler.Middleware(next).ServeHTTP(response, request)
+
+	if response.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", response.Code, http.StatusOK)
+	}
+	if gotUsername != "alice" {
+		t.Errorf("username in context = %q, want %q", gotUsername, "alice")
+	}
+}
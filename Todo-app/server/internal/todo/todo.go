@@ -0,0 +1,285 @@
+// Package todo holds the HTTP handlers for the todo resource. The handlers
+// depend only on the TodoStorer interface, not on any particular database
+// driver, so they can be tested against an in-memory fake.
+package todo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"todo-app/server/auth"
+)
+
+const (
+	defaultPage  = 1
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+// ErrNotFound is returned by a TodoStorer when no todo matches the given id
+// and owner. Controller maps it to a 404; any other error is a 500.
+var ErrNotFound = errors.New("todo: not found")
+
+// Todo is a single todo item, scoped to the user that owns it.
+type Todo struct {
+	ID        primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
+	Title     string             `json:"title,omitempty" bson:"title,omitempty"`
+	Completed bool               `json:"completed,omitempty" bson:"completed,omitempty"`
+	Owner     string             `json:"owner,omitempty" bson:"owner,omitempty"`
+	CreatedAt time.Time          `json:"created_at,omitempty" bson:"created_at,omitempty"`
+	UpdatedAt time.Time          `json:"updated_at,omitempty" bson:"updated_at,omitempty"`
+}
+
+// ListFilter narrows and orders a FindAll call. A zero value means "no
+// filtering, default ordering, everything in one page" is left to the
+// storer to decide.
+type ListFilter struct {
+	Page      int64
+	Limit     int64
+	Completed *bool
+	Query     string
+	SortField string
+	SortDesc  bool
+}
+
+// ListResult is a single page of todos plus the total count matching the
+// filter, so callers can compute how many pages remain.
+type ListResult struct {
+	Items []Todo
+	Total int64
+}
+
+// TodoStorer is the persistence boundary the Controller depends on. It's
+// implemented by mongodb.TodoStorage in production and can be faked in
+// tests.
+type TodoStorer interface {
+	Create(ctx context.Context, todo *Todo) (primitive.ObjectID, error)
+	FindAll(ctx context.Context, owner string, filter ListFilter) (ListResult, error)
+	FindByID(ctx context.Context, id primitive.ObjectID, owner string) (*Todo, error)
+	Update(ctx context.Context, id primitive.ObjectID, owner string, todo *Todo) error
+	Patch(ctx context.Context, id primitive.ObjectID, owner string, fields map[string]interface{}) error
+	Delete(ctx context.Context, id primitive.ObjectID, owner string) error
+}
+
+// Controller exposes the todo CRUD endpoints backed by a TodoStorer.
+type Controller struct {
+	storer TodoStorer
+}
+
+// NewController builds a Controller around the given storer.
+func NewController(storer TodoStorer) *Controller {
+	return &Controller{storer: storer}
+}
+
+func (c *Controller) CreateTodoEndpoint(response http.ResponseWriter, request *http.Request) {
+	response.Header().Set("Content-Type", "application/json")
+	var t Todo
+	if err := json.NewDecoder(request.Body).Decode(&t); err != nil {
+		response.WriteHeader(http.StatusBadRequest)
+		response.Write([]byte(`{"message": "Invalid request payload"}`))
+		return
+	}
+	owner, _ := auth.UserIDFromContext(request.Context())
+	t.Owner = owner
+	t.CreatedAt = time.Now()
+	t.UpdatedAt = t.CreatedAt
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	id, err := c.storer.Create(ctx, &t)
+	if err != nil {
+		response.WriteHeader(http.StatusInternalServerError)
+		response.Write([]byte(`{"message": "Failed to insert todo"}`))
+		return
+	}
+	response.WriteHeader(http.StatusCreated)
+	json.NewEncoder(response).Encode(id)
+}
+
+func (c *Controller) GetTodosEndpoint(response http.ResponseWriter, request *http.Request) {
+	response.Header().Set("Content-Type", "application/json")
+	owner, _ := auth.UserIDFromContext(request.Context())
+	filter := parseListFilter(request)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := c.storer.FindAll(ctx, owner, filter)
+	if err != nil {
+		response.WriteHeader(http.StatusInternalServerError)
+		response.Write([]byte(`{"message": "Failed to fetch todos"}`))
+		return
+	}
+	json.NewEncoder(response).Encode(map[string]interface{}{
+		"items": result.Items,
+		"page":  filter.Page,
+		"limit": filter.Limit,
+		"total": result.Total,
+	})
+}
+
+// parseListFilter reads ?page=, ?limit=, ?completed=, ?q= and ?sort=field:dir
+// from the request into a ListFilter, falling back to sane defaults.
+func parseListFilter(request *http.Request) ListFilter {
+	query := request.URL.Query()
+
+	filter := ListFilter{Page: defaultPage, Limit: defaultLimit}
+	if page, err := strconv.ParseInt(query.Get("page"), 10, 64); err == nil && page > 0 {
+		filter.Page = page
+	}
+	if limit, err := strconv.ParseInt(query.Get("limit"), 10, 64); err == nil && limit > 0 && limit <= maxLimit {
+		filter.Limit = limit
+	}
+	if completed, err := strconv.ParseBool(query.Get("completed")); err == nil {
+		filter.Completed = &completed
+	}
+	filter.Query = query.Get("q")
+
+	if sort := query.Get("sort"); sort != "" {
+		field, dir, _ := strings.Cut(sort, ":")
+		filter.SortField = field
+		filter.SortDesc = dir == "desc"
+	}
+
+	return filter
+}
+
+func (c *Controller) GetTodoEndpoint(response http.ResponseWriter, request *http.Request) {
+	response.Header().Set("Content-Type", "application/json")
+	params := mux.Vars(request)
+	id, _ := primitive.ObjectIDFromHex(params["id"])
+	owner, _ := auth.UserIDFromContext(request.Context())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	t, err := c.storer.FindByID(ctx, id, owner)
+	if errors.Is(err, ErrNotFound) {
+		response.WriteHeader(http.StatusNotFound)
+		response.Write([]byte(`{"message": "Todo not found"}`))
+		return
+	}
+	if err != nil {
+		response.WriteHeader(http.StatusInternalServerError)
+		response.Write([]byte(`{"message": "Failed to fetch todo"}`))
+		return
+	}
+	json.NewEncoder(response).Encode(t)
+}
+
+func (c *Controller) UpdateTodoEndpoint(response http.ResponseWriter, request *http.Request) {
+	response.Header().Set("Content-Type", "application/json")
+	params := mux.Vars(request)
+	id, _ := primitive.ObjectIDFromHex(params["id"])
+	owner, _ := auth.UserIDFromContext(request.Context())
+
+	var t Todo
+	if err := json.NewDecoder(request.Body).Decode(&t); err != nil {
+		response.WriteHeader(http.StatusBadRequest)
+		response.Write([]byte(`{"message": "Invalid request payload"}`))
+		return
+	}
+	t.Owner = owner
+	t.UpdatedAt = time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := c.storer.Update(ctx, id, owner, &t)
+	if errors.Is(err, ErrNotFound) {
+		response.WriteHeader(http.StatusNotFound)
+		response.Write([]byte(`{"message": "Todo not found"}`))
+		return
+	}
+	if err != nil {
+		response.WriteHeader(http.StatusInternalServerError)
+		response.Write([]byte(`{"message": "Failed to update todo"}`))
+		return
+	}
+	json.NewEncoder(response).Encode(t)
+}
+
+// PatchTodoEndpoint applies a sparse update: only fields present in the
+// request body are touched, so a client flipping `completed` doesn't need
+// to resend the title (and can't accidentally wipe it).
+func (c *Controller) PatchTodoEndpoint(response http.ResponseWriter, request *http.Request) {
+	response.Header().Set("Content-Type", "application/json")
+	params := mux.Vars(request)
+	id, _ := primitive.ObjectIDFromHex(params["id"])
+	owner, _ := auth.UserIDFromContext(request.Context())
+
+	var raw map[string]json.RawMessage
+	if err := json.NewDecoder(request.Body).Decode(&raw); err != nil {
+		response.WriteHeader(http.StatusBadRequest)
+		response.Write([]byte(`{"message": "Invalid request payload"}`))
+		return
+	}
+
+	fields := map[string]interface{}{}
+	if v, ok := raw["title"]; ok {
+		var title string
+		if err := json.Unmarshal(v, &title); err != nil {
+			response.WriteHeader(http.StatusBadRequest)
+			response.Write([]byte(`{"message": "Invalid title"}`))
+			return
+		}
+		fields["title"] = title
+	}
+	if v, ok := raw["completed"]; ok {
+		var completed bool
+		if err := json.Unmarshal(v, &completed); err != nil {
+			response.WriteHeader(http.StatusBadRequest)
+			response.Write([]byte(`{"message": "Invalid completed"}`))
+			return
+		}
+		fields["completed"] = completed
+	}
+	if len(fields) == 0 {
+		response.WriteHeader(http.StatusBadRequest)
+		response.Write([]byte(`{"message": "No updatable fields provided"}`))
+		return
+	}
+	fields["updated_at"] = time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := c.storer.Patch(ctx, id, owner, fields)
+	if errors.Is(err, ErrNotFound) {
+		response.WriteHeader(http.StatusNotFound)
+		response.Write([]byte(`{"message": "Todo not found"}`))
+		return
+	}
+	if err != nil {
+		response.WriteHeader(http.StatusInternalServerError)
+		response.Write([]byte(`{"message": "Failed to update todo"}`))
+		return
+	}
+	json.NewEncoder(response).Encode(map[string]string{"message": "Todo updated successfully"})
+}
+
+func (c *Controller) DeleteTodoEndpoint(response http.ResponseWriter, request *http.Request) {
+	response.Header().Set("Content-Type", "application/json")
+	params := mux.Vars(request)
+	id, _ := primitive.ObjectIDFromHex(params["id"])
+	owner, _ := auth.UserIDFromContext(request.Context())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := c.storer.Delete(ctx, id, owner)
+	if errors.Is(err, ErrNotFound) {
+		response.WriteHeader(http.StatusNotFound)
+		response.Write([]byte(`{"message": "Todo not found"}`))
+		return
+	}
+	if err != nil {
+		response.WriteHeader(http.StatusInternalServerError)
+		response.Write([]byte(`{"message": "Failed to delete todo"}`))
+		return
+	}
+	json.NewEncoder(response).Encode("Todo deleted successfully")
+}
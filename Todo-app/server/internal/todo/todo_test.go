@@ -0,0 +1,126 @@
+package todo
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeStorer is an in-memory TodoStorer used to exercise Controller
+// handlers without a real MongoDB instance.
+type fakeStorer struct {
+	patchedID     primitive.ObjectID
+	patchedFields map[string]interface{}
+}
+
+func (f *fakeStorer) Create(ctx context.Context, t *Todo) (primitive.ObjectID, error) {
+	return primitive.NewObjectID(), nil
+}
+
+func (f *fakeStorer) FindAll(ctx context.Context, owner string, filter ListFilter) (ListResult, error) {
+	return ListResult{}, nil
+}
+
+func (f *fakeStorer) FindByID(ctx context.Context, id primitive.ObjectID, owner string) (*Todo, error) {
+	return nil, ErrNotFound
+}
+
+func (f *fakeStorer) Update(ctx context.Context, id primitive.ObjectID, owner string, t *Todo) error {
+	return nil
+}
+
+func (f *fakeStorer) Patch(ctx context.Context, id primitive.ObjectID, owner string, fields map[string]interface{}) error {
+	f.patchedID = id
+	f.patchedFields = fields
+	return nil
+}
+
+func (f *fakeStorer) Delete(ctx context.Context, id primitive.ObjectID, owner string) error {
+	return nil
+}
+
+func TestParseListFilterDefaults(t *testing.T) {
+	request := httptest.NewRequest("GET", "/todos", nil)
+	filter := parseListFilter(request)
+
+	if filter.Page != defaultPage {
+		t.Errorf("Page = %d, want %d", filter.Page, defaultPage)
+	}
+	if filter.Limit != defaultLimit {
+		t.Errorf("Limit = %d, want %d", filter.Limit, defaultLimit)
+	}
+	if filter.Completed != nil {
+		t.Errorf("Completed = %v, want nil", filter.Completed)
+	}
+}
+
+func TestParseListFilterOverrides(t *testing.T) {
+	request := httptest.NewRequest("GET", "/todos?page=2&limit=5&completed=true&q=milk&sort=created_at:desc", nil)
+	filter := parseListFilter(request)
+
+	if filter.Page != 2 {
+		t.Errorf("Page = %d, want 2", filter.Page)
+	}
+	if filter.Limit != 5 {
+		t.Errorf("Limit = %d, want 5", filter.Limit)
+	}
+	if filter.Completed == nil || *filter.Completed != true {
+		t.Errorf("Completed = %v, want true", filter.Completed)
+	}
+	if filter.Query != "milk" {
+		t.Errorf("Query = %q, want %q", filter.Query, "milk")
+	}
+	if filter.SortField != "created_at" || !filter.SortDesc {
+		t.Errorf("SortField/SortDesc = %q/%v, want created_at/true", filter.SortField, filter.SortDesc)
+	}
+}
+
+func TestParseListFilterLimitAboveMaxIsIgnored(t *testing.T) {
+	request := httptest.NewRequest("GET", "/todos?limit=1000", nil)
+	filter := parseListFilter(request)
+
+	if filter.Limit != defaultLimit {
+		t.Errorf("Limit = %d, want default %d when over max", filter.Limit, defaultLimit)
+	}
+}
+
+func TestPatchTodoEndpointOnlyTouchesProvidedFields(t *testing.T) {
+	storer := &fakeStorer{}
+	controller := NewController(storer)
+
+	id := primitive.NewObjectID()
+	request := httptest.NewRequest("PATCH", "/todos/"+id.Hex(), bytes.NewBufferString(`{"completed": true}`))
+	request = mux.SetURLVars(request, map[string]string{"id": id.Hex()})
+	response := httptest.NewRecorder()
+
+	controller.PatchTodoEndpoint(response, request)
+
+	if response.Code != 200 {
+		t.Fatalf("status = %d, want 200", response.Code)
+	}
+	if _, ok := storer.patchedFields["title"]; ok {
+		t.Errorf("patchedFields contains title, want only completed/updated_at")
+	}
+	if completed, ok := storer.patchedFields["completed"].(bool); !ok || !completed {
+		t.Errorf("patchedFields[completed] = %v, want true", storer.patchedFields["completed"])
+	}
+}
+
+func TestPatchTodoEndpointRejectsEmptyBody(t *testing.T) {
+	controller := NewController(&fakeStorer{})
+
+	id := primitive.NewObjectID()
+	request := httptest.NewRequest("PATCH", "/todos/"+id.Hex(), bytes.NewBufferString(`{}`))
+	request = mux.SetURLVars(request, map[string]string{"id": id.Hex()})
+	response := httptest.NewRecorder()
+
+	controller.PatchTodoEndpoint(response, request)
+
+	if response.Code != 400 {
+		t.Fatalf("status = %d, want 400", response.Code)
+	}
+}
@@ -5,184 +5,139 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
 	"time"
-	
+
 	"github.com/gorilla/mux"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"todo-app/server/auth"
+	"todo-app/server/internal/todo"
+	"todo-app/server/middleware"
+	"todo-app/server/mongodb"
+	"todo-app/server/ui"
 )
 
-type Todo struct {
-	ID primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty"`
-	Title string `json:"title,omitempty" bson:"title,omitempty"`
-	Completed bool `json:"completed,omitempty" bson:"completed,omitempty"`
-}
+// idPattern constrains the {id} path variable to a 24-character hex Mongo
+// ObjectID, so it never shadows literal routes like GET /todos/new.
+const idPattern = "{id:[0-9a-fA-F]{24}}"
 
-var client *mongo.Client
+// jsonContentTypePattern matches "application/json" with an optional
+// parameter such as "; charset=utf-8", unlike an exact Headers() match.
+const jsonContentTypePattern = "^application/json(;.*)?$"
 
 func main() {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	clientOptions := options.Client().ApplyURI("mongodb://localhost:27017")
-	var err error
-	client, err = mongo.Connect(ctx, clientOptions)
+	client, err := mongodb.NewDatabase(mongodb.DatabaseConfig{
+		Host:    "localhost",
+		Port:    "27017",
+		DBName:  "todos-app",
+		Timeout: 10 * time.Second,
+	})
 	if err != nil {
 		log.Fatalf("Error connecting to MongoDB: %v", err)
 	}
 	defer func() {
-		if err = client.Disconnect(ctx); err != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := client.Disconnect(ctx); err != nil {
 			log.Fatalf("Error disconnecting from MongoDB: %v", err)
 		}
 	}()
+	log.Println("Connected to MongoDB!")
 
-	
-	err = client.Ping(ctx, nil)
-	if err != nil {
-		log.Fatalf("Failed to ping MongoDB: %v", err)
+	authHandler := auth.NewHandler(client)
+	indexCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if err := authHandler.EnsureIndexes(indexCtx); err != nil {
+		log.Fatalf("Failed to create users index: %v", err)
 	}
-	log.Println("Connected to MongoDB!")
+	cancel()
 
-	
-	router := mux.NewRouter()
-	
-	router.Use(func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Access-Control-Allow-Origin", "http://localhost:5173") 
-			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
-
-			if r.Method == "OPTIONS" {
-				w.WriteHeader(http.StatusOK)
-				return
-			}
-
-			next.ServeHTTP(w, r)
-		})
-	})
+	controller := todo.NewController(mongodb.NewTodoStorage(client, "todos-app"))
 
+	router := mux.NewRouter()
 
-	router.HandleFunc("/todos", CreateTodoEndpoint).Methods("POST")
-	router.HandleFunc("/todos", GetTodosEndpoint).Methods("GET")
-	router.HandleFunc("/todos/{id}", GetTodoEndpoint).Methods("GET")
-	router.HandleFunc("/todos/{id}", UpdateTodoEndpoint).Methods("PUT")
-	router.HandleFunc("/todos/{id}", DeleteTodoEndpoint).Methods("DELETE")
+	router.Use(middleware.RequestID)
+	router.Use(middleware.Logger)
+	router.Use(middleware.Recover)
+	router.Use(middleware.CORS(middleware.CORSConfig{
+		AllowedOrigins: []string{"http://localhost:5173"},
+	}))
+
+	router.HandleFunc("/register", authHandler.RegisterEndpoint).Methods("POST")
+	router.HandleFunc("/login", authHandler.LoginEndpoint).Methods("POST").HeadersRegexp("Content-Type", jsonContentTypePattern)
+
+	// The JSON API is registered before the UI below: its /todos POST route
+	// is content-type gated, and must be tried first or the UI's form
+	// equivalent on the same path would shadow it. HeadersRegexp (not an
+	// exact Headers match) so "application/json; charset=utf-8" still
+	// matches, not just the bare media type.
+	todos := router.PathPrefix("").Subrouter()
+	todos.Use(authHandler.Middleware)
+	todos.HandleFunc("/todos", controller.CreateTodoEndpoint).Methods("POST").HeadersRegexp("Content-Type", jsonContentTypePattern)
+	todos.HandleFunc("/todos", controller.GetTodosEndpoint).Methods("GET")
+	todos.HandleFunc("/todos/"+idPattern, controller.GetTodoEndpoint).Methods("GET")
+	todos.HandleFunc("/todos/"+idPattern, controller.UpdateTodoEndpoint).Methods("PUT")
+	todos.HandleFunc("/todos/"+idPattern, controller.PatchTodoEndpoint).Methods("PATCH")
+	todos.HandleFunc("/todos/"+idPattern, controller.DeleteTodoEndpoint).Methods("DELETE")
+
+	uiHandler, err := ui.NewHandler(mongodb.NewTodoStorage(client, "todos-app"), authHandler, "templates")
+	if err != nil {
+		log.Fatalf("Failed to load UI templates: %v", err)
+	}
+	csrfAuthKey := []byte(os.Getenv("CSRF_AUTH_KEY"))
+	if len(csrfAuthKey) == 0 {
+		csrfAuthKey = []byte("dev-csrf-auth-key-32-bytes-long!")
+	}
+	secureCookies, _ := strconv.ParseBool(os.Getenv("SECURE_COOKIES"))
+	uiHandler.Routes(router, csrfAuthKey, secureCookies)
 
+	router.HandleFunc("/healthz", healthzEndpoint).Methods("GET")
+	router.HandleFunc("/readyz", readyzEndpoint(client)).Methods("GET")
 
-	log.Fatal(http.ListenAndServe(":12345", router))
-}
+	server := &http.Server{Addr: ":12345", Handler: router}
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-func CreateTodoEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("Content-Type", "application/json")
-	var todo Todo
-	err := json.NewDecoder(request.Body).Decode(&todo)
-	if err != nil {
-		response.WriteHeader(http.StatusBadRequest)
-		response.Write([]byte(`{"message": "Invalid request payload"}`))
-		return
-	}
-	collection := client.Database("todos-app").Collection("todos")
-	ctx, _ := context.WithTimeout(context.Background(), 5*time.Second)
-	result, err := collection.InsertOne(ctx, todo)
-	if err != nil {
-		response.WriteHeader(http.StatusInternalServerError)
-		response.Write([]byte(`{"message": "Failed to insert todo"}`))
-		return
-	}
-	response.WriteHeader(http.StatusCreated)
-	json.NewEncoder(response).Encode(result.InsertedID)
-}
+	go func() {
+		log.Printf("Listening on %s", server.Addr)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("ListenAndServe: %v", err)
+		}
+	}()
 
-func GetTodosEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("Content-Type", "application/json")
-	var todos []Todo
-	collection := client.Database("todos-app").Collection("todos")
-	ctx, _ := context.WithTimeout(context.Background(), 5*time.Second)
-	cursor, err := collection.Find(ctx, bson.M{})
-	if err != nil {
-		response.WriteHeader(http.StatusInternalServerError)
-		response.Write([]byte(`{"message": "Failed to fetch todos"}`))
-		return
-	}
-	defer cursor.Close(ctx)
-	for cursor.Next(ctx) {
-		var todo Todo
-		cursor.Decode(&todo)
-		todos = append(todos, todo)
-	}
-	if err := cursor.Err(); err != nil {
-		response.WriteHeader(http.StatusInternalServerError)
-		response.Write([]byte(`{"message": "Cursor error"}`))
-		return
-	}
-	json.NewEncoder(response).Encode(todos)
-}
+	<-ctx.Done()
+	log.Println("Shutting down...")
 
-func GetTodoEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("Content-Type", "application/json")
-	params := mux.Vars(request)
-	id, _ := primitive.ObjectIDFromHex(params["id"])
-	var todo Todo
-	collection := client.Database("todos-app").Collection("todos")
-	ctx, _ := context.WithTimeout(context.Background(), 5*time.Second)
-	err := collection.FindOne(ctx, Todo{ID: id}).Decode(&todo)
-	if err != nil {
-		response.WriteHeader(http.StatusNotFound)
-		response.Write([]byte(`{"message": "Todo not found"}`))
-		return
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer shutdownCancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Error during shutdown: %v", err)
 	}
-	json.NewEncoder(response).Encode(todo)
 }
 
-
-func UpdateTodoEndpoint(response http.ResponseWriter, request *http.Request) {
+// healthzEndpoint reports process liveness: if this handler runs, the
+// process is up. It never touches MongoDB.
+func healthzEndpoint(response http.ResponseWriter, request *http.Request) {
 	response.Header().Set("Content-Type", "application/json")
-	params := mux.Vars(request)
-	id, _ := primitive.ObjectIDFromHex(params["id"])
-	var todo Todo
-	err := json.NewDecoder(request.Body).Decode(&todo)
-	if err != nil {
-		response.WriteHeader(http.StatusBadRequest)
-		response.Write([]byte(`{"message": "Invalid request payload"}`))
-		return
-	}
-	collection := client.Database("todos-app").Collection("todos")
-	ctx, _ := context.WithTimeout(context.Background(), 5*time.Second)
-	filter := bson.M{"_id": id}
-	update := bson.M{"$set": todo}
-	result, err := collection.UpdateOne(ctx, filter, update)
-	if err != nil {
-		response.WriteHeader(http.StatusInternalServerError)
-		response.Write([]byte(`{"message": "Failed to update todo"}`))
-		return
-	}
-	if result.ModifiedCount == 0 {
-		response.WriteHeader(http.StatusNotFound)
-		response.Write([]byte(`{"message": "Todo not found"}`))
-		return
-	}
-	json.NewEncoder(response).Encode(todo)
+	json.NewEncoder(response).Encode(map[string]string{"status": "ok"})
 }
 
-func DeleteTodoEndpoint(response http.ResponseWriter, request *http.Request) {
-	response.Header().Set("Content-Type", "application/json")
-	params := mux.Vars(request)
-	id, _ := primitive.ObjectIDFromHex(params["id"])
-	collection := client.Database("todos-app").Collection("todos")
-	ctx, _ := context.WithTimeout(context.Background(), 5*time.Second)
-	result, err := collection.DeleteOne(ctx, bson.M{"_id": id})
-	if err != nil {
-		response.WriteHeader(http.StatusInternalServerError)
-		response.Write([]byte(`{"message": "Failed to delete todo"}`))
-		return
-	}
-	if result.DeletedCount == 0 {
-		response.WriteHeader(http.StatusNotFound)
-		response.Write([]byte(`{"message": "Todo not found"}`))
-		return
+// readyzEndpoint reports whether the service can currently reach MongoDB.
+func readyzEndpoint(client *mongo.Client) http.HandlerFunc {
+	return func(response http.ResponseWriter, request *http.Request) {
+		response.Header().Set("Content-Type", "application/json")
+		ctx, cancel := context.WithTimeout(request.Context(), time.Second)
+		defer cancel()
+
+		if err := client.Ping(ctx, nil); err != nil {
+			response.WriteHeader(http.StatusServiceUnavailable)
+			json.NewEncoder(response).Encode(map[string]string{"status": "unavailable"})
+			return
+		}
+		json.NewEncoder(response).Encode(map[string]string{"status": "ready"})
 	}
-	json.NewEncoder(response).Encode("Todo deleted successfully")
 }
@@ -0,0 +1,144 @@
+// Package middleware provides the composable http.Handler wrappers the
+// service chains together in main.go: request IDs, structured logging,
+// panic recovery, and configurable CORS.
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type contextKey string
+
+const requestIDKey contextKey = "requestID"
+
+// RequestID generates a UUID per request, sets it on the X-Request-ID
+// response header, and stores it in the request context for downstream
+// middleware (Logger, Recover) and handlers to read.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		id := uuid.NewString()
+		response.Header().Set("X-Request-ID", id)
+		ctx := context.WithValue(request.Context(), requestIDKey, id)
+		next.ServeHTTP(response, request.WithContext(ctx))
+	})
+}
+
+// RequestIDFromContext returns the request id set by RequestID, if any.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey).(string)
+	return id, ok
+}
+
+// statusRecorder wraps a ResponseWriter to capture the status code written,
+// since http.ResponseWriter doesn't expose it after the fact.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Logger emits one JSON line per request with the method, path, status,
+// duration and request id. Intended to sit inside RequestID in the chain.
+func Logger(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: response, status: http.StatusOK}
+
+		next.ServeHTTP(recorder, request)
+
+		requestID, _ := RequestIDFromContext(request.Context())
+		entry, err := json.Marshal(map[string]interface{}{
+			"method":      request.Method,
+			"path":        request.URL.Path,
+			"status":      recorder.status,
+			"duration_ms": time.Since(start).Milliseconds(),
+			"request_id":  requestID,
+		})
+		if err != nil {
+			log.Printf(`{"message": "failed to marshal log entry: %v"}`, err)
+			return
+		}
+		log.Println(string(entry))
+	})
+}
+
+// Recover catches panics from downstream handlers, logs them, and returns a
+// 500 carrying the request id instead of crashing the process.
+func Recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				requestID, _ := RequestIDFromContext(request.Context())
+				log.Printf("panic handling %s %s: %v", request.Method, request.URL.Path, recovered)
+				response.Header().Set("Content-Type", "application/json")
+				response.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(response).Encode(map[string]string{
+					"message":    "Internal server error",
+					"request_id": requestID,
+				})
+			}
+		}()
+		next.ServeHTTP(response, request)
+	})
+}
+
+// CORSConfig drives the CORS middleware. AllowedOrigins is matched against
+// the request's Origin header rather than echoed as a single constant, so
+// the service can serve more than one frontend.
+type CORSConfig struct {
+	AllowedOrigins []string
+	AllowedMethods []string
+	AllowedHeaders []string
+}
+
+// CORS builds a CORS middleware from cfg.
+func CORS(cfg CORSConfig) func(http.Handler) http.Handler {
+	allowed := make(map[string]bool, len(cfg.AllowedOrigins))
+	for _, origin := range cfg.AllowedOrigins {
+		allowed[origin] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+			origin := request.Header.Get("Origin")
+			if allowed[origin] || allowed["*"] {
+				if allowed["*"] {
+					response.Header().Set("Access-Control-Allow-Origin", "*")
+				} else {
+					response.Header().Set("Access-Control-Allow-Origin", origin)
+					response.Header().Set("Vary", "Origin")
+				}
+				response.Header().Set("Access-Control-Allow-Methods", joinOrDefault(cfg.AllowedMethods, "GET, POST, PUT, PATCH, DELETE, OPTIONS"))
+				response.Header().Set("Access-Control-Allow-Headers", joinOrDefault(cfg.AllowedHeaders, "Content-Type, Authorization"))
+			}
+
+			if request.Method == http.MethodOptions {
+				response.WriteHeader(http.StatusOK)
+				return
+			}
+
+			next.ServeHTTP(response, request)
+		})
+	}
+}
+
+func joinOrDefault(values []string, fallback string) string {
+	if len(values) == 0 {
+		return fallback
+	}
+	joined := values[0]
+	for _, v := range values[1:] {
+		joined += ", " + v
+	}
+	return joined
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCORSAllowsConfiguredOrigin(t *testing.T) {
+	handler := CORS(CORSConfig{AllowedOrigins: []string{"http://localhost:5173"}})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+
+	request := httptest.NewRequest("GET", "/todos", nil)
+	request.Header.Set("Origin", "http://localhost:5173")
+	response := httptest.NewRecorder()
+
+	handler.ServeHTTP(response, request)
+
+	if got := response.Header().Get("Access-Control-Allow-Origin"); got != "http://localhost:5173" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, "http://localhost:5173")
+	}
+}
+
+func TestCORSRejectsUnknownOrigin(t *testing.T) {
+	handler := CORS(CORSConfig{AllowedOrigins: []string{"http://localhost:5173"}})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+
+	request := httptest.NewRequest("GET", "/todos", nil)
+	request.Header.Set("Origin", "http://evil.example")
+	response := httptest.NewRecorder()
+
+	handler.ServeHTTP(response, request)
+
+	if got := response.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want empty for disallowed origin", got)
+	}
+}
+
+func TestRecoverCatchesPanic(t *testing.T) {
+	handler := RequestID(Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})))
+
+	request := httptest.NewRequest("GET", "/todos", nil)
+	response := httptest.NewRecorder()
+
+	handler.ServeHTTP(response, request)
+
+	if response.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", response.Code, http.StatusInternalServerError)
+	}
+}
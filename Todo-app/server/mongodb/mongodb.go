@@ -0,0 +1,54 @@
+// Package mongodb provides the MongoDB-backed implementations of the
+// storage interfaces used by the rest of the service.
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DatabaseConfig holds the connection parameters for a MongoDB deployment.
+// It replaces the hard-coded "mongodb://localhost:27017" URI so the service
+// can be pointed at a real cluster without code changes.
+type DatabaseConfig struct {
+	AuthMechanism string
+	Host          string
+	Port          string
+	User          string
+	Pass          string
+	DBName        string
+	Timeout       time.Duration
+}
+
+// NewDatabase connects to MongoDB using cfg and verifies the connection
+// with a ping before returning.
+func NewDatabase(cfg DatabaseConfig) (*mongo.Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	clientOptions := options.Client().ApplyURI(cfg.uri())
+	client, err := mongo.Connect(ctx, clientOptions)
+	if err != nil {
+		return nil, fmt.Errorf("mongodb: connect: %w", err)
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, fmt.Errorf("mongodb: ping: %w", err)
+	}
+	return client, nil
+}
+
+func (cfg DatabaseConfig) uri() string {
+	if cfg.User == "" {
+		return fmt.Sprintf("mongodb://%s:%s", cfg.Host, cfg.Port)
+	}
+	uri := fmt.Sprintf("mongodb://%s:%s@%s:%s", url.QueryEscape(cfg.User), url.QueryEscape(cfg.Pass), cfg.Host, cfg.Port)
+	if cfg.AuthMechanism != "" {
+		uri += "/?authMechanism=" + cfg.AuthMechanism
+	}
+	return uri
+}
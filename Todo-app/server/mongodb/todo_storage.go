@@ -0,0 +1,138 @@
+package mongodb
+
+import (
+	"context"
+	"regexp"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"todo-app/server/internal/todo"
+)
+
+// TodoStorage implements todo.TodoStorer against a MongoDB collection.
+type TodoStorage struct {
+	collection *mongo.Collection
+}
+
+// NewTodoStorage builds a TodoStorage backed by the "todos" collection of
+// the given database.
+func NewTodoStorage(client *mongo.Client, dbName string) *TodoStorage {
+	return &TodoStorage{collection: client.Database(dbName).Collection("todos")}
+}
+
+func (s *TodoStorage) Create(ctx context.Context, t *todo.Todo) (primitive.ObjectID, error) {
+	result, err := s.collection.InsertOne(ctx, t)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	id, _ := result.InsertedID.(primitive.ObjectID)
+	return id, nil
+}
+
+func (s *TodoStorage) FindAll(ctx context.Context, owner string, filter todo.ListFilter) (todo.ListResult, error) {
+	query := bson.M{"owner": owner}
+	if filter.Completed != nil {
+		query["completed"] = *filter.Completed
+	}
+	if filter.Query != "" {
+		query["title"] = bson.M{"$regex": regexp.QuoteMeta(filter.Query), "$options": "i"}
+	}
+
+	total, err := s.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return todo.ListResult{}, err
+	}
+
+	sortField := filter.SortField
+	if sortField == "" {
+		sortField = "_id"
+	}
+	sortDir := 1
+	if filter.SortDesc {
+		sortDir = -1
+	}
+
+	opts := options.Find().
+		SetSkip((filter.Page - 1) * filter.Limit).
+		SetLimit(filter.Limit).
+		SetSort(bson.D{{Key: sortField, Value: sortDir}})
+
+	cursor, err := s.collection.Find(ctx, query, opts)
+	if err != nil {
+		return todo.ListResult{}, err
+	}
+	defer cursor.Close(ctx)
+
+	var todos []todo.Todo
+	for cursor.Next(ctx) {
+		var t todo.Todo
+		if err := cursor.Decode(&t); err != nil {
+			return todo.ListResult{}, err
+		}
+		todos = append(todos, t)
+	}
+	if err := cursor.Err(); err != nil {
+		return todo.ListResult{}, err
+	}
+
+	return todo.ListResult{Items: todos, Total: total}, nil
+}
+
+func (s *TodoStorage) FindByID(ctx context.Context, id primitive.ObjectID, owner string) (*todo.Todo, error) {
+	var t todo.Todo
+	err := s.collection.FindOne(ctx, bson.M{"_id": id, "owner": owner}).Decode(&t)
+	if err == mongo.ErrNoDocuments {
+		return nil, todo.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (s *TodoStorage) Update(ctx context.Context, id primitive.ObjectID, owner string, t *todo.Todo) error {
+	filter := bson.M{"_id": id, "owner": owner}
+	// Set fields explicitly rather than $set-ing the whole decoded struct:
+	// that would blindly overwrite created_at with its zero value whenever
+	// a client omits it.
+	set := bson.M{
+		"title":      t.Title,
+		"completed":  t.Completed,
+		"owner":      t.Owner,
+		"updated_at": t.UpdatedAt,
+	}
+	result, err := s.collection.UpdateOne(ctx, filter, bson.M{"$set": set})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return todo.ErrNotFound
+	}
+	return nil
+}
+
+func (s *TodoStorage) Patch(ctx context.Context, id primitive.ObjectID, owner string, fields map[string]interface{}) error {
+	filter := bson.M{"_id": id, "owner": owner}
+	result, err := s.collection.UpdateOne(ctx, filter, bson.M{"$set": fields})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return todo.ErrNotFound
+	}
+	return nil
+}
+
+func (s *TodoStorage) Delete(ctx context.Context, id primitive.ObjectID, owner string) error {
+	result, err := s.collection.DeleteOne(ctx, bson.M{"_id": id, "owner": owner})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return todo.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+// Package ui renders a server-side HTML frontend for the todo API. It
+// shares the same TodoStorer and auth.Handler as the JSON endpoints, so
+// operators can drive the service from a browser without the Vite frontend
+// at :5173.
+package ui
+
+import (
+	"context"
+	"errors"
+	"html/template"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/gorilla/csrf"
+	"github.com/gorilla/mux"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"todo-app/server/auth"
+	"todo-app/server/internal/todo"
+)
+
+const sessionCookie = "session_token"
+
+// Handler renders the HTML pages and handles their form posts.
+type Handler struct {
+	storer        todo.TodoStorer
+	auth          *auth.Handler
+	templates     *template.Template
+	secureCookies bool
+}
+
+// NewHandler parses the templates under templatesDir and builds a Handler
+// backed by storer and authHandler.
+func NewHandler(storer todo.TodoStorer, authHandler *auth.Handler, templatesDir string) (*Handler, error) {
+	tmpl, err := template.ParseGlob(filepath.Join(templatesDir, "*.html"))
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{storer: storer, auth: authHandler, templates: tmpl}, nil
+}
+
+// Routes registers the UI's pages and form posts on router behind CSRF
+// protection. csrfAuthKey must be 32 bytes; secureCookies should be true
+// whenever the service is served over HTTPS, and also governs the Secure
+// flag on the session cookie set by LoginSubmitEndpoint.
+func (h *Handler) Routes(router *mux.Router, csrfAuthKey []byte, secureCookies bool) {
+	h.secureCookies = secureCookies
+	protect := csrf.Protect(csrfAuthKey, csrf.Secure(secureCookies), csrf.Path("/"))
+
+	pages := router.PathPrefix("").Subrouter()
+	pages.Use(protect)
+
+	pages.HandleFunc("/login", h.LoginPageEndpoint).Methods("GET")
+	pages.HandleFunc("/login", h.LoginSubmitEndpoint).Methods("POST")
+	pages.HandleFunc("/todos/new", h.NewTodoPageEndpoint).Methods("GET")
+	pages.HandleFunc("/todos", h.CreateTodoEndpoint).Methods("POST")
+	pages.HandleFunc("/todos/{id}/complete", h.CompleteTodoEndpoint).Methods("POST")
+	pages.HandleFunc("/todos/{id}/delete", h.DeleteTodoEndpoint).Methods("POST")
+	pages.HandleFunc("/", h.IndexEndpoint).Methods("GET")
+}
+
+func (h *Handler) currentUser(request *http.Request) (string, bool) {
+	cookie, err := request.Cookie(sessionCookie)
+	if err != nil {
+		return "", false
+	}
+	username, err := h.auth.VerifyToken(cookie.Value)
+	if err != nil {
+		return "", false
+	}
+	return username, true
+}
+
+// requireUser redirects anonymous visitors to the login page. Callers
+// should return immediately when ok is false.
+func (h *Handler) requireUser(response http.ResponseWriter, request *http.Request) (string, bool) {
+	username, ok := h.currentUser(request)
+	if !ok {
+		http.Redirect(response, request, "/login", http.StatusSeeOther)
+	}
+	return username, ok
+}
+
+func (h *Handler) render(response http.ResponseWriter, request *http.Request, name string, data map[string]interface{}) {
+	data["CSRFField"] = csrf.TemplateField(request)
+	response.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := h.templates.ExecuteTemplate(response, name, data); err != nil {
+		http.Error(response, "Failed to render page", http.StatusInternalServerError)
+	}
+}
+
+// IndexEndpoint lists the signed-in user's todos.
+func (h *Handler) IndexEndpoint(response http.ResponseWriter, request *http.Request) {
+	username, ok := h.requireUser(response, request)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(request.Context(), 5*time.Second)
+	defer cancel()
+	result, err := h.storer.FindAll(ctx, username, todo.ListFilter{Page: 1, Limit: 100})
+	if err != nil {
+		http.Error(response, "Failed to load todos", http.StatusInternalServerError)
+		return
+	}
+
+	h.render(response, request, "todos-list.html", map[string]interface{}{
+		"Username": username,
+		"Todos":    result.Items,
+	})
+}
+
+// NewTodoPageEndpoint renders the form for adding a todo.
+func (h *Handler) NewTodoPageEndpoint(response http.ResponseWriter, request *http.Request) {
+	if _, ok := h.requireUser(response, request); !ok {
+		return
+	}
+	h.render(response, request, "todo-new.html", map[string]interface{}{})
+}
+
+// CreateTodoEndpoint handles the form post from NewTodoPageEndpoint.
+func (h *Handler) CreateTodoEndpoint(response http.ResponseWriter, request *http.Request) {
+	username, ok := h.requireUser(response, request)
+	if !ok {
+		return
+	}
+	if err := request.ParseForm(); err != nil {
+		http.Error(response, "Invalid form", http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now()
+	t := todo.Todo{
+		Title:     request.FormValue("title"),
+		Owner:     username,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	ctx, cancel := context.WithTimeout(request.Context(), 5*time.Second)
+	defer cancel()
+	if _, err := h.storer.Create(ctx, &t); err != nil {
+		http.Error(response, "Failed to create todo", http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(response, request, "/", http.StatusSeeOther)
+}
+
+// CompleteTodoEndpoint marks a todo as completed.
+func (h *Handler) CompleteTodoEndpoint(response http.ResponseWriter, request *http.Request) {
+	username, ok := h.requireUser(response, request)
+	if !ok {
+		return
+	}
+	id, err := primitive.ObjectIDFromHex(mux.Vars(request)["id"])
+	if err != nil {
+		http.Error(response, "Invalid todo id", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(request.Context(), 5*time.Second)
+	defer cancel()
+	err = h.storer.Patch(ctx, id, username, map[string]interface{}{
+		"completed":  true,
+		"updated_at": time.Now(),
+	})
+	if err != nil && !errors.Is(err, todo.ErrNotFound) {
+		http.Error(response, "Failed to update todo", http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(response, request, "/", http.StatusSeeOther)
+}
+
+// DeleteTodoEndpoint removes a todo.
+func (h *Handler) DeleteTodoEndpoint(response http.ResponseWriter, request *http.Request) {
+	username, ok := h.requireUser(response, request)
+	if !ok {
+		return
+	}
+	id, err := primitive.ObjectIDFromHex(mux.Vars(request)["id"])
+	if err != nil {
+		http.Error(response, "Invalid todo id", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(request.Context(), 5*time.Second)
+	defer cancel()
+	if err := h.storer.Delete(ctx, id, username); err != nil && !errors.Is(err, todo.ErrNotFound) {
+		http.Error(response, "Failed to delete todo", http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(response, request, "/", http.StatusSeeOther)
+}
+
+// LoginPageEndpoint renders the login form.
+func (h *Handler) LoginPageEndpoint(response http.ResponseWriter, request *http.Request) {
+	h.render(response, request, "login.html", map[string]interface{}{})
+}
+
+// LoginSubmitEndpoint handles the login form post, setting a session cookie
+// that carries the same JWT issued by the JSON /login endpoint.
+func (h *Handler) LoginSubmitEndpoint(response http.ResponseWriter, request *http.Request) {
+	if err := request.ParseForm(); err != nil {
+		http.Error(response, "Invalid form", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(request.Context(), 5*time.Second)
+	defer cancel()
+	username, err := h.auth.Authenticate(ctx, request.FormValue("username"), request.FormValue("password"))
+	if err != nil {
+		h.render(response, request, "login.html", map[string]interface{}{"Error": "Invalid username or password"})
+		return
+	}
+
+	token, err := h.auth.IssueToken(username)
+	if err != nil {
+		http.Error(response, "Failed to log in", http.StatusInternalServerError)
+		return
+	}
+
+	http.SetCookie(response, &http.Cookie{
+		Name:     sessionCookie,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   h.secureCookies,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   int((24 * time.Hour).Seconds()),
+	})
+	http.Redirect(response, request, "/", http.StatusSeeOther)
+}